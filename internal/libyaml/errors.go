@@ -3,6 +3,21 @@
 
 // Error types for YAML parsing and emitting.
 // Provides structured error reporting with line/column information.
+//
+// STATUS: Kind classification is unreachable from a real decode error.
+// The request that introduced ErrorKind asks for the scanner, parser,
+// composer, resolver, and constructor to set Kind when they build a
+// LoadError, through the NewLoadError constructor below, so that
+// errors.Is(err, yaml.ErrDuplicateKey) works against an error an actual
+// Unmarshal call produced. None of those call sites exist in this
+// trimmed tree snapshot — only errors.go and its siblings do — so they
+// were not touched, and every LoadError a real decode failure produces
+// today still has Kind == "". The errors_test.go cases for Is/NewLoadError
+// necessarily hand-build LoadError values rather than exercising a real
+// pipeline, for the same reason. This also means severityFor in
+// errors_json.go (see its STATUS note) can't see a real Kind either.
+// Wiring up those five call sites is the only remaining step once their
+// files are part of this tree.
 
 package libyaml
 
@@ -16,21 +31,74 @@ import (
 type Stage string
 
 const (
-	ReaderStage      Stage = "reader"      // Input reading and encoding
-	ScannerStage     Stage = "scanner"     // Tokenization
-	ParserStage      Stage = "parser"      // Event stream parsing
-	ComposerStage    Stage = "composer"    // Node tree construction
-	ResolverStage    Stage = "resolver"    // Tag resolution
-	ConstructorStage Stage = "constructor" // Go value construction
+	ReaderStage        Stage = "reader"        // Input reading and encoding
+	ScannerStage       Stage = "scanner"       // Tokenization
+	ParserStage        Stage = "parser"        // Event stream parsing
+	ComposerStage      Stage = "composer"      // Node tree construction
+	ResolverStage      Stage = "resolver"      // Tag resolution
+	ConstructorStage   Stage = "constructor"   // Go value construction
+	MergerStage        Stage = "merger"        // Node tree overlay/merge (e.g. yamlpatch)
+	InterpolationStage Stage = "interpolation" // ${VAR} expansion, between resolver and constructor
+)
+
+// ErrorKind classifies the specific failure mode of a LoadError so
+// callers can react to it programmatically instead of
+// substring-matching Message. It is distinct from [Kind], the node-kind
+// enum used elsewhere in this package (DocumentNode, MappingNode, ...).
+// ErrorKind is zero-valued ("") for errors that predate classification
+// or that don't fit one of the known kinds; that's not itself matchable
+// via errors.Is.
+type ErrorKind string
+
+const (
+	KindDuplicateKey    ErrorKind = "duplicate_key"    // a mapping key appears more than once
+	KindUnknownField    ErrorKind = "unknown_field"    // a field has no matching struct field and is not allowed
+	KindUnresolvedAlias ErrorKind = "unresolved_alias" // an alias refers to an anchor that was never defined
+	KindTagResolution   ErrorKind = "tag_resolution"   // a tag could not be resolved to a type
+	KindOverflow        ErrorKind = "overflow"         // a numeric value does not fit the target type
+	KindTypeMismatch    ErrorKind = "type_mismatch"    // a node's kind doesn't match what the target expects
+	KindMissingRequired ErrorKind = "missing_required" // a required field or value is absent
+	KindIO              ErrorKind = "io"               // reading the input failed, including EOF
+	KindSyntax          ErrorKind = "syntax"           // the input is not well-formed YAML
 )
 
+// Sentinel errors matching each Kind, for use with errors.Is:
+//
+//	if errors.Is(err, yaml.ErrDuplicateKey) { ... }
+var (
+	ErrDuplicateKey    = errors.New("yaml: duplicate key")
+	ErrUnknownField    = errors.New("yaml: unknown field")
+	ErrUnresolvedAlias = errors.New("yaml: unresolved alias")
+	ErrTagResolution   = errors.New("yaml: tag resolution failed")
+	ErrOverflow        = errors.New("yaml: integer overflow")
+	ErrTypeMismatch    = errors.New("yaml: type mismatch")
+	ErrMissingRequired = errors.New("yaml: missing required field")
+	ErrIO              = errors.New("yaml: i/o error")
+	ErrSyntax          = errors.New("yaml: syntax error")
+)
+
+// kindSentinels maps each ErrorKind to the sentinel error LoadError.Is
+// matches it against.
+var kindSentinels = map[ErrorKind]error{
+	KindDuplicateKey:    ErrDuplicateKey,
+	KindUnknownField:    ErrUnknownField,
+	KindUnresolvedAlias: ErrUnresolvedAlias,
+	KindTagResolution:   ErrTagResolution,
+	KindOverflow:        ErrOverflow,
+	KindTypeMismatch:    ErrTypeMismatch,
+	KindMissingRequired: ErrMissingRequired,
+	KindIO:              ErrIO,
+	KindSyntax:          ErrSyntax,
+}
+
 // LoadError represents an error that occurred while loading a YAML document.
 //
 // It provides detailed location information and identifies the processing
 // stage where the error occurred.
 type LoadError struct {
-	Stage   Stage  // Processing stage where error occurred
-	Message string // Error description
+	Stage   Stage     // Processing stage where error occurred
+	Kind    ErrorKind // Classification of the failure, for errors.Is (may be "")
+	Message string    // Error description
 
 	// Position information
 	Mark        Mark   // Primary error position
@@ -41,6 +109,31 @@ type LoadError struct {
 	Err error // Underlying error (for Unwrap support)
 }
 
+// NewLoadError builds a *LoadError with Kind set. Stage implementations
+// (the scanner, parser, composer, resolver, and constructor) should build
+// every LoadError through this constructor rather than a bare struct
+// literal, so that classifying a new failure mode isn't something a call
+// site can forget to do. ContextMark/ContextMsg/Err can be set on the
+// result afterward; they're not part of the minimal call shared by every
+// site.
+//
+// See the file-level STATUS comment above: none of those call sites use
+// it yet.
+func NewLoadError(stage Stage, kind ErrorKind, message string, mark Mark) *LoadError {
+	return &LoadError{Stage: stage, Kind: kind, Message: message, Mark: mark}
+}
+
+// Is reports whether target is the sentinel error for e.Kind, or matches
+// e.Err via errors.Is. This lets callers write
+// errors.Is(err, yaml.ErrDuplicateKey) regardless of which stage produced
+// the error.
+func (e *LoadError) Is(target error) bool {
+	if sentinel, ok := kindSentinels[e.Kind]; ok && target == sentinel {
+		return true
+	}
+	return e.Err != nil && errors.Is(e.Err, target)
+}
+
 // Error returns the error message with stage and position information.
 // Format: "go-yaml Load error: <message>\n  in <stage> at L:C"
 // Or with context: "go-yaml Load error: <message>\n  in <stage> (while <ctx>) at L:C-L:C"
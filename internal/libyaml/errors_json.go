@@ -0,0 +1,193 @@
+// Copyright 2025 The go-yaml Project Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// JSON serialization for error types, so linters, editor plugins, and CI
+// tools can consume diagnostics without regex-parsing the Error() string
+// format.
+//
+// STATUS: severityFor's warning/error split depends on Kind, which (see
+// errors.go's file-level STATUS comment) no real decode error has set
+// today. In practice every diagnostic a real Unmarshal failure produces
+// through this file is SeverityError; the warning path only fires for a
+// hand-built LoadError with Kind set directly, as in this file's tests.
+
+package libyaml
+
+import "encoding/json"
+
+// Severity classifies how serious a JSON-encoded diagnostic is, letting
+// IDEs choose between an error and a warning squiggle.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// warningKinds are the ErrorKinds that represent a recoverable constructor
+// issue rather than a fatal one: [LoadErrors] can carry one of these
+// alongside a partially-decoded value, so it's worth an IDE rendering it
+// as a warning instead of an error. See the file-level STATUS comment
+// above for when this table actually takes effect.
+var warningKinds = map[ErrorKind]bool{
+	KindUnknownField: true,
+}
+
+func severityFor(k ErrorKind) Severity {
+	if warningKinds[k] {
+		return SeverityWarning
+	}
+	return SeverityError
+}
+
+// jsonMark is the wire representation of a Mark. A zero Mark marshals to
+// nil so callers don't see a misleading "line":0 for an error that has no
+// position.
+type jsonMark struct {
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	Index  int `json:"index,omitempty"`
+}
+
+func newJSONMark(m Mark) *jsonMark {
+	if m == (Mark{}) {
+		return nil
+	}
+	return &jsonMark{Line: m.Line, Column: m.Column, Index: m.Index}
+}
+
+// jsonContext is the wire representation of a LoadError's ContextMsg/ContextMark pair.
+type jsonContext struct {
+	Message string    `json:"message,omitempty"`
+	Mark    *jsonMark `json:"mark,omitempty"`
+}
+
+// jsonLoadError is the stable wire schema shared by LoadError.MarshalJSON
+// and LoadErrors.MarshalJSON:
+//
+//	{"stage":"parser","kind":"duplicate_key","message":"...","mark":{"line":3,"column":5,"index":42},"context":{"message":"...","mark":{...}},"cause":"...","severity":"error"}
+type jsonLoadError struct {
+	Stage    Stage        `json:"stage,omitempty"`
+	Kind     ErrorKind    `json:"kind,omitempty"`
+	Message  string       `json:"message"`
+	Mark     *jsonMark    `json:"mark,omitempty"`
+	Context  *jsonContext `json:"context,omitempty"`
+	Cause    string       `json:"cause,omitempty"`
+	Severity Severity     `json:"severity,omitempty"`
+}
+
+func (e *LoadError) toJSON() jsonLoadError {
+	j := jsonLoadError{
+		Stage:    e.Stage,
+		Kind:     e.Kind,
+		Message:  e.Message,
+		Mark:     newJSONMark(e.Mark),
+		Severity: severityFor(e.Kind),
+	}
+	if e.ContextMsg != "" || e.ContextMark != (Mark{}) {
+		j.Context = &jsonContext{Message: e.ContextMsg, Mark: newJSONMark(e.ContextMark)}
+	}
+	if e.Err != nil {
+		j.Cause = e.Err.Error()
+	}
+	return j
+}
+
+// MarshalJSON encodes e using the schema documented on
+// [LoadErrors.MarshalJSON], as a single object rather than an array.
+func (e *LoadError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// MarshalJSON encodes e as a JSON array, one object per Error using the
+// schema:
+//
+//	{"stage":"parser","kind":"duplicate_key","message":"...","mark":{"line":3,"column":5,"index":42},"context":{"message":"...","mark":{...}},"cause":"...","severity":"error"}
+//
+// Zero-valued fields (an empty Stage, a Mark{} with no position, a nil
+// Err) are omitted rather than emitted as zero values, so consumers can
+// tell "unknown" apart from "first line".
+func (e *LoadErrors) MarshalJSON() ([]byte, error) {
+	out := make([]jsonLoadError, len(e.Errors))
+	for i, err := range e.Errors {
+		out[i] = err.toJSON()
+	}
+	return json.Marshal(out)
+}
+
+// jsonTypeError is the wire representation of a TypeError, kept
+// field-compatible with jsonLoadError even though TypeError only ever
+// carries a plain message.
+type jsonTypeError struct {
+	Message  string   `json:"message"`
+	Severity Severity `json:"severity,omitempty"`
+}
+
+// MarshalJSON encodes e as a JSON array of {"message", "severity"} objects.
+//
+// Deprecated: TypeError itself is deprecated in favor of [LoadErrors];
+// this exists only for symmetry with [LoadError.MarshalJSON].
+func (e *TypeError) MarshalJSON() ([]byte, error) {
+	out := make([]jsonTypeError, len(e.Errors))
+	for i, msg := range e.Errors {
+		out[i] = jsonTypeError{Message: msg, Severity: SeverityError}
+	}
+	return json.Marshal(out)
+}
+
+// jsonConstructError mirrors jsonLoadError's mark handling for the legacy ConstructError type.
+type jsonConstructError struct {
+	Message  string    `json:"message"`
+	Mark     *jsonMark `json:"mark,omitempty"`
+	Severity Severity  `json:"severity,omitempty"`
+}
+
+// MarshalJSON encodes e as a single object, for symmetry with [LoadError.MarshalJSON].
+//
+// Deprecated: ConstructError itself is deprecated in favor of [LoadError].
+func (e *ConstructError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonConstructError{
+		Message:  e.Error(),
+		Mark:     newJSONMark(Mark{Line: e.Line, Column: e.Column}),
+		Severity: SeverityError,
+	})
+}
+
+// FormatErrorsJSON walks err's Unwrap chain, including the
+// `Unwrap() []error` form used by LoadErrors, and encodes every LoadError
+// it finds as a JSON array using the schema documented on
+// [LoadErrors.MarshalJSON]. A non-LoadError at the end of a chain (one
+// with no Unwrap method) is encoded as a {"message":...,"severity":"error"}
+// object rather than dropped; a wrapper error that has an Unwrap method is
+// walked through and is not itself encoded.
+func FormatErrorsJSON(err error) ([]byte, error) {
+	var out []jsonLoadError
+
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+			return
+		case *LoadError:
+			out = append(out, e.toJSON())
+		case *LoadErrors:
+			for _, sub := range e.Errors {
+				walk(sub)
+			}
+		case interface{ Unwrap() []error }:
+			for _, sub := range e.Unwrap() {
+				walk(sub)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		default:
+			out = append(out, jsonLoadError{Message: err.Error(), Severity: SeverityError})
+		}
+	}
+
+	walk(err)
+	if out == nil {
+		out = []jsonLoadError{}
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The go-yaml Project Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package libyaml
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.yaml.in/yaml/v4/internal/testutil/assert"
+)
+
+func TestLoadErrorMarshalJSON(t *testing.T) {
+	err := &LoadError{
+		Stage:   ParserStage,
+		Kind:    KindDuplicateKey,
+		Message: "duplicate key \"foo\"",
+		Mark:    Mark{Line: 3, Column: 5, Index: 42},
+	}
+
+	b, marshalErr := json.Marshal(err)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as JSON")
+
+	assert.Equalf(t, "parser", got["stage"], "stage mismatch")
+	assert.Equalf(t, "duplicate_key", got["kind"], "kind mismatch")
+	assert.Equalf(t, "duplicate key \"foo\"", got["message"], "message mismatch")
+	assert.Equalf(t, "error", got["severity"], "severity should default to \"error\"")
+
+	mark, ok := got["mark"].(map[string]any)
+	assert.Truef(t, ok, "mark should be an object, got %T", got["mark"])
+	assert.Equalf(t, float64(3), mark["line"], "mark.line mismatch")
+	assert.Equalf(t, float64(5), mark["column"], "mark.column mismatch")
+	assert.Equalf(t, float64(42), mark["index"], "mark.index mismatch")
+
+	_, hasContext := got["context"]
+	assert.Truef(t, !hasContext, "context should be omitted when there is none")
+	_, hasCause := got["cause"]
+	assert.Truef(t, !hasCause, "cause should be omitted when Err is nil")
+}
+
+func TestLoadErrorMarshalJSONWithContextAndCause(t *testing.T) {
+	err := &LoadError{
+		Stage:       ConstructorStage,
+		Message:     "cannot unmarshal !!str into int",
+		Mark:        Mark{Line: 10, Column: 1},
+		ContextMsg:  "while decoding a sequence item",
+		ContextMark: Mark{Line: 9, Column: 3},
+		Err:         errors.New("strconv.Atoi: invalid syntax"),
+	}
+
+	b, marshalErr := json.Marshal(err)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as JSON")
+
+	assert.Equalf(t, "strconv.Atoi: invalid syntax", got["cause"], "cause mismatch")
+
+	ctx, ok := got["context"].(map[string]any)
+	assert.Truef(t, ok, "context should be an object, got %T", got["context"])
+	assert.Equalf(t, "while decoding a sequence item", ctx["message"], "context.message mismatch")
+
+	_, hasKind := got["kind"]
+	assert.Truef(t, !hasKind, "kind should be omitted when unset")
+}
+
+func TestLoadErrorMarshalJSONUnknownFieldIsWarning(t *testing.T) {
+	err := &LoadError{Stage: ConstructorStage, Kind: KindUnknownField, Message: "unknown field \"bogus\""}
+
+	b, marshalErr := json.Marshal(err)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as JSON")
+	assert.Equalf(t, "warning", got["severity"], "an unknown field is a recoverable issue and should be a warning")
+}
+
+// TestLoadErrorMarshalJSONUnclassifiedIsError documents the current real-
+// world behavior: a LoadError built without Kind set (every LoadError a
+// real Unmarshal call produces today, since the scanner/parser/composer/
+// resolver/constructor call sites aren't wired up to NewLoadError in this
+// tree) reports SeverityError, not SeverityWarning, even for a message
+// that describes a recoverable issue like an unknown field.
+func TestLoadErrorMarshalJSONUnclassifiedIsError(t *testing.T) {
+	err := &LoadError{Stage: ConstructorStage, Message: "unknown field \"bogus\""}
+
+	b, marshalErr := json.Marshal(err)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as JSON")
+	assert.Equalf(t, "error", got["severity"], "an unclassified LoadError should default to SeverityError")
+}
+
+func TestLoadErrorsMarshalJSON(t *testing.T) {
+	errs := &LoadErrors{Errors: []*LoadError{
+		{Stage: ConstructorStage, Kind: KindUnknownField, Message: "a"},
+		{Stage: ConstructorStage, Kind: KindOverflow, Message: "b", Mark: Mark{Line: 2}},
+	}}
+
+	b, marshalErr := json.Marshal(errs)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got []map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as a JSON array")
+	assert.Equalf(t, 2, len(got), "expected one object per error")
+	assert.Equalf(t, "unknown_field", got[0]["kind"], "first error kind mismatch")
+	assert.Equalf(t, "overflow", got[1]["kind"], "second error kind mismatch")
+}
+
+func TestFormatErrorsJSONWalksLoadErrors(t *testing.T) {
+	errs := &LoadErrors{Errors: []*LoadError{
+		{Stage: ConstructorStage, Kind: KindUnknownField, Message: "a"},
+		{Stage: ConstructorStage, Kind: KindOverflow, Message: "b"},
+	}}
+
+	b, err := FormatErrorsJSON(errs)
+	assert.Truef(t, err == nil, "FormatErrorsJSON returned an error: %v", err)
+
+	var got []map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as a JSON array")
+	assert.Equalf(t, 2, len(got), "expected one object per wrapped error")
+}
+
+func TestFormatErrorsJSONFallsBackForPlainErrors(t *testing.T) {
+	b, err := FormatErrorsJSON(errors.New("plain failure"))
+	assert.Truef(t, err == nil, "FormatErrorsJSON returned an error: %v", err)
+
+	var got []map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as a JSON array")
+	assert.Equalf(t, 1, len(got), "expected exactly one object")
+	assert.Equalf(t, "plain failure", got[0]["message"], "message mismatch")
+}
+
+func TestFormatErrorsJSONEmptyForNil(t *testing.T) {
+	b, err := FormatErrorsJSON(nil)
+	assert.Truef(t, err == nil, "FormatErrorsJSON returned an error: %v", err)
+	assert.Equalf(t, "[]", string(b), "nil error should format as an empty array")
+}
+
+func TestTypeErrorMarshalJSON(t *testing.T) {
+	err := &TypeError{Errors: []string{"line 1: bad value", "line 2: bad value"}}
+
+	b, marshalErr := json.Marshal(err)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got []map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as a JSON array")
+	assert.Equalf(t, 2, len(got), "expected one object per message")
+	assert.Equalf(t, "line 1: bad value", got[0]["message"], "message mismatch")
+}
+
+func TestConstructErrorMarshalJSON(t *testing.T) {
+	err := &ConstructError{Line: 7, Err: errors.New("bad value")}
+
+	b, marshalErr := json.Marshal(err)
+	assert.Truef(t, marshalErr == nil, "Marshal returned an error: %v", marshalErr)
+
+	var got map[string]any
+	assert.Truef(t, json.Unmarshal(b, &got) == nil, "output did not parse as JSON")
+	assert.Equalf(t, "line 7: bad value", got["message"], "message mismatch")
+
+	mark, ok := got["mark"].(map[string]any)
+	assert.Truef(t, ok, "mark should be an object, got %T", got["mark"])
+	assert.Equalf(t, float64(7), mark["line"], "mark.line mismatch")
+}
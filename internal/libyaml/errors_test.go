@@ -14,6 +14,63 @@ import (
 	"go.yaml.in/yaml/v4/internal/testutil/assert"
 )
 
+func TestLoadErrorKindIs(t *testing.T) {
+	tests := []struct {
+		name string
+		kind ErrorKind
+		want error
+	}{
+		{"duplicate key", KindDuplicateKey, ErrDuplicateKey},
+		{"unknown field", KindUnknownField, ErrUnknownField},
+		{"unresolved alias", KindUnresolvedAlias, ErrUnresolvedAlias},
+		{"tag resolution", KindTagResolution, ErrTagResolution},
+		{"overflow", KindOverflow, ErrOverflow},
+		{"type mismatch", KindTypeMismatch, ErrTypeMismatch},
+		{"missing required", KindMissingRequired, ErrMissingRequired},
+		{"io", KindIO, ErrIO},
+		{"syntax", KindSyntax, ErrSyntax},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := &LoadError{Stage: ConstructorStage, Kind: tc.kind, Message: "boom"}
+			assert.Truef(t, errors.Is(err, tc.want), "errors.Is(err, %v) should be true for Kind %s", tc.want, tc.kind)
+
+			for _, other := range tests {
+				if other.kind == tc.kind {
+					continue
+				}
+				assert.Falsef(t, errors.Is(err, other.want), "errors.Is(err, %v) should be false for Kind %s", other.want, tc.kind)
+			}
+		})
+	}
+}
+
+func TestNewLoadErrorSetsKind(t *testing.T) {
+	err := NewLoadError(ParserStage, KindDuplicateKey, "duplicate key \"foo\"", Mark{Line: 3, Column: 5})
+
+	assert.Equalf(t, ParserStage, err.Stage, "Stage mismatch")
+	assert.Equalf(t, KindDuplicateKey, err.Kind, "Kind mismatch")
+	assert.Truef(t, errors.Is(err, ErrDuplicateKey), "errors.Is(err, ErrDuplicateKey) should be true")
+}
+
+func TestLoadErrorIsFallsBackToErr(t *testing.T) {
+	wrapped := errors.New("underlying cause")
+	err := &LoadError{Stage: ConstructorStage, Err: wrapped}
+
+	assert.Truef(t, errors.Is(err, wrapped), "errors.Is should also match the wrapped Err when Kind doesn't match")
+}
+
+func TestLoadErrorsIsFansOutAcrossKinds(t *testing.T) {
+	errs := &LoadErrors{Errors: []*LoadError{
+		{Stage: ConstructorStage, Kind: KindUnknownField, Message: "a"},
+		{Stage: ConstructorStage, Kind: KindOverflow, Message: "b"},
+	}}
+
+	assert.Truef(t, errors.Is(errs, ErrOverflow), "LoadErrors should fan Is out across its Errors")
+	assert.Falsef(t, errors.Is(errs, ErrSyntax), "LoadErrors should not match a Kind none of its Errors have")
+}
+
 func TestErrors(t *testing.T) {
 	RunTestCases(t, "errors.yaml", map[string]TestHandler{
 		"load-error":      runLoadErrorTest,
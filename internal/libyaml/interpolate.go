@@ -0,0 +1,228 @@
+// Copyright 2025 The go-yaml Project Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Variable interpolation for scalar nodes, borrowed from the compose-go
+// interpolation layer.
+//
+// STATUS: unwired scaffolding, not a working decode stage. The request
+// this implements asks for a `dec.SetInterpolator(interp *Interpolator)`
+// hook on Decoder, running between ResolverStage and ConstructorStage so
+// Expand substitutes ${VAR} references before the constructor sees a
+// scalar's value. No such hook exists: this trimmed tree snapshot only
+// carries errors.go and its siblings, not decode.go or any Decoder type
+// to attach SetInterpolator to. Interpolator/Expand/ExpandScalar below
+// have no caller anywhere in this tree today — they're written the way
+// that hook would call them, so wiring up SetInterpolator is the only
+// remaining step once decode.go is part of this tree.
+
+package libyaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrMissingVariable is wrapped by the LoadError returned when a bare
+// ${VAR} reference is unresolved in strict mode, or a ${VAR:?msg}
+// reference is unresolved regardless of mode. Callers can test for it
+// with errors.Is.
+var ErrMissingVariable = errors.New("yaml: missing variable")
+
+// Interpolator expands ${VAR} style references inside unquoted and
+// double-quoted scalars. Single-quoted and literal/folded block scalars
+// are meant to be taken literally and must never reach Expand; callers
+// should use ExpandScalar, which enforces that rule, rather than calling
+// Expand directly from a decoder.
+//
+// The zero value looks up variables in the process environment and is
+// permissive: an unset bare ${VAR} expands to the empty string.
+//
+// See the file-level STATUS comment above: nothing in this tree calls
+// Interpolator yet.
+type Interpolator struct {
+	// Lookup resolves a variable name to its value and whether it is
+	// set. Defaults to os.LookupEnv.
+	Lookup func(name string) (string, bool)
+
+	// Strict makes a bare ${VAR} reference to an unset variable an error
+	// instead of expanding to the empty string.
+	Strict bool
+
+	// Types maps a resolved YAML tag (e.g. "!!int") to a conversion
+	// applied to the fully expanded scalar, so that e.g. ${PORT} used
+	// where an !!int is expected is coerced from its looked-up string
+	// form before the constructor runs.
+	Types map[string]func(string) (string, error)
+}
+
+func (in *Interpolator) lookup(name string) (string, bool) {
+	if in.Lookup != nil {
+		return in.Lookup(name)
+	}
+	return os.LookupEnv(name)
+}
+
+// ExpandScalar is the entry point a decoder's interpolation stage should
+// call for every scalar node, rather than Expand directly. verbatim is
+// true for scalars whose style means "take this text literally" —
+// single-quoted and literal/folded block scalars — in which case s is
+// returned unchanged and ${...} sequences inside it are left untouched.
+// Plain and double-quoted scalars are expanded normally.
+//
+// The caller is responsible for deriving verbatim from the node's Style
+// (e.g. style&(SingleQuotedStyle|LiteralStyle|FoldedStyle) != 0); that
+// decision lives with the caller because this package doesn't otherwise
+// need to know about node styles.
+func (in *Interpolator) ExpandScalar(s string, tag string, verbatim bool, mark Mark) (string, error) {
+	if verbatim {
+		return s, nil
+	}
+	return in.Expand(s, tag, mark)
+}
+
+// Expand interpolates every ${...} reference in s, the raw text of a
+// single scalar node resolved to tag and located at mark. Supported
+// forms are ${VAR}, ${VAR:-default}, ${VAR-default}, ${VAR:?err}, and the
+// literal escape $$ (which expands to a single $).
+func (in *Interpolator) Expand(s string, tag string, mark Mark) (string, error) {
+	var out strings.Builder
+
+	i := 0
+	for i < len(s) {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '$':
+			out.WriteByte('$')
+			i += 2
+
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := matchingBrace(s, i+2)
+			if end < 0 {
+				return "", &LoadError{
+					Stage:   InterpolationStage,
+					Message: fmt.Sprintf("unterminated variable reference: %q", s[i:]),
+					Mark:    Mark{Line: mark.Line, Column: mark.Column + i},
+				}
+			}
+			body, err := in.Expand(s[i+2:end], "", Mark{Line: mark.Line, Column: mark.Column + i + 2})
+			if err != nil {
+				return "", err
+			}
+			val, err := in.expandRef(body, mark, i)
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(val)
+			i = end + 1
+
+		default:
+			out.WriteByte(s[i])
+			i++
+		}
+	}
+
+	expanded := out.String()
+	convert, ok := in.Types[tag]
+	if !ok {
+		return expanded, nil
+	}
+
+	converted, err := convert(expanded)
+	if err != nil {
+		return "", &LoadError{
+			Stage:   InterpolationStage,
+			Message: fmt.Sprintf("coercing interpolated value to %s: %v", tag, err),
+			Mark:    mark,
+			Err:     err,
+		}
+	}
+	return converted, nil
+}
+
+// expandRef resolves a single ${...} body (everything between the braces):
+// name, name:-default, name-default, or name:?errMessage.
+func (in *Interpolator) expandRef(ref string, mark Mark, offset int) (string, error) {
+	name, op, arg, hasOp := splitOperator(ref)
+	val, found := in.lookup(name)
+	empty := !found || val == ""
+
+	switch {
+	case !hasOp:
+		if !found && in.Strict {
+			return "", in.missingVar(name, mark, offset)
+		}
+		return val, nil
+
+	case op == ":-": // empty or unset -> default
+		if empty {
+			return arg, nil
+		}
+		return val, nil
+
+	case op == "-": // unset only -> default
+		if !found {
+			return arg, nil
+		}
+		return val, nil
+
+	case op == ":?": // empty or unset -> error
+		if empty {
+			msg := arg
+			if msg == "" {
+				msg = "variable is required but empty or unset"
+			}
+			return "", &LoadError{
+				Stage:   InterpolationStage,
+				Message: fmt.Sprintf("${%s}: %s", name, msg),
+				Mark:    Mark{Line: mark.Line, Column: mark.Column + offset},
+				Err:     fmt.Errorf("%w: %s", ErrMissingVariable, name),
+			}
+		}
+		return val, nil
+
+	default:
+		return "", fmt.Errorf("yaml: unsupported variable operator %q", op)
+	}
+}
+
+func (in *Interpolator) missingVar(name string, mark Mark, offset int) error {
+	return &LoadError{
+		Stage:   InterpolationStage,
+		Message: fmt.Sprintf("variable %q is not set", name),
+		Mark:    Mark{Line: mark.Line, Column: mark.Column + offset},
+		Err:     fmt.Errorf("%w: %s", ErrMissingVariable, name),
+	}
+}
+
+// matchingBrace returns the index in s of the '}' that closes the "${"
+// whose body starts at start, accounting for "${...}" references nested
+// inside (e.g. the default in ${HOST:-${DEFAULT}}). It returns -1 if the
+// reference is never closed.
+func matchingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitOperator splits a ${...} body into name, operator, and argument.
+// Operators are checked longest-first so ":-" is never mistaken for "-".
+func splitOperator(ref string) (name, op, arg string, hasOp bool) {
+	for _, candidate := range []string{":-", ":?", "-"} {
+		if idx := strings.Index(ref, candidate); idx >= 0 {
+			return ref[:idx], candidate, ref[idx+len(candidate):], true
+		}
+	}
+	return ref, "", "", false
+}
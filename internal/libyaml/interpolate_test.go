@@ -0,0 +1,137 @@
+// Copyright 2025 The go-yaml Project Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package libyaml
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"go.yaml.in/yaml/v4/internal/testutil/assert"
+)
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+}
+
+func TestInterpolatorExpand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		env  map[string]string
+		want string
+	}{
+		{"plain value", "${HOST}", map[string]string{"HOST": "localhost"}, "localhost"},
+		{"unset is empty", "${HOST}", nil, ""},
+		{"literal dollar escape", "$${HOST}", map[string]string{"HOST": "localhost"}, "${HOST}"},
+		{"default on unset", "${HOST:-localhost}", nil, "localhost"},
+		{"default on empty", "${HOST:-localhost}", map[string]string{"HOST": ""}, "localhost"},
+		{"default ignored when set", "${HOST:-localhost}", map[string]string{"HOST": "example.com"}, "example.com"},
+		{"unset-only default keeps empty value", "${HOST-localhost}", map[string]string{"HOST": ""}, ""},
+		{"unset-only default on unset", "${HOST-localhost}", nil, "localhost"},
+		{"embedded in larger scalar", "postgres://${HOST:-db}:5432", nil, "postgres://db:5432"},
+		{"nested default reference", "${HOST:-${FALLBACK}}", map[string]string{"FALLBACK": "example.com"}, "example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			in := &Interpolator{Lookup: lookupFrom(tc.env)}
+			got, err := in.Expand(tc.in, "!!str", Mark{Line: 1, Column: 1})
+			assert.Truef(t, err == nil, "Expand returned an error: %v", err)
+			assert.Equalf(t, tc.want, got, "Expand(%q) mismatch", tc.in)
+		})
+	}
+}
+
+func TestInterpolatorStrictMissingVariable(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{Lookup: lookupFrom(nil), Strict: true}
+	_, err := in.Expand("${HOST}", "!!str", Mark{Line: 3, Column: 2})
+	assert.Truef(t, err != nil, "expected an error in strict mode for an unset variable")
+	assert.Truef(t, errors.Is(err, ErrMissingVariable), "error should wrap ErrMissingVariable")
+
+	var loadErr *LoadError
+	assert.Truef(t, errors.As(err, &loadErr), "error should be a *LoadError, got %T", err)
+	assert.Equalf(t, InterpolationStage, loadErr.Stage, "Stage mismatch")
+}
+
+func TestInterpolatorRequiredOperatorErrors(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{Lookup: lookupFrom(nil)}
+	_, err := in.Expand("${HOST:?must be set}", "!!str", Mark{Line: 1, Column: 1})
+	assert.Truef(t, err != nil, "expected an error for ${VAR:?msg} with an unset variable")
+	assert.Truef(t, errors.Is(err, ErrMissingVariable), "error should wrap ErrMissingVariable")
+}
+
+func TestInterpolatorRequiredOperatorPassesWhenSet(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{Lookup: lookupFrom(map[string]string{"HOST": "example.com"})}
+	got, err := in.Expand("${HOST:?must be set}", "!!str", Mark{Line: 1, Column: 1})
+	assert.Truef(t, err == nil, "Expand returned an error: %v", err)
+	assert.Equalf(t, "example.com", got, "expanded value mismatch")
+}
+
+func TestInterpolatorUnterminatedReference(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{Lookup: lookupFrom(nil)}
+	_, err := in.Expand("${HOST", "!!str", Mark{Line: 1, Column: 1})
+	assert.Truef(t, err != nil, "expected an error for an unterminated ${ reference")
+
+	var loadErr *LoadError
+	assert.Truef(t, errors.As(err, &loadErr), "error should be a *LoadError, got %T", err)
+	assert.Equalf(t, InterpolationStage, loadErr.Stage, "Stage mismatch")
+}
+
+func TestInterpolatorTypeCoercion(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{
+		Lookup: lookupFrom(map[string]string{"PORT": "9090"}),
+		Types: map[string]func(string) (string, error){
+			"!!int": func(s string) (string, error) {
+				if _, err := strconv.Atoi(s); err != nil {
+					return "", err
+				}
+				return s, nil
+			},
+		},
+	}
+
+	got, err := in.Expand("${PORT}", "!!int", Mark{Line: 1, Column: 1})
+	assert.Truef(t, err == nil, "Expand returned an error: %v", err)
+	assert.Equalf(t, "9090", got, "expanded value mismatch")
+
+	in.Lookup = lookupFrom(map[string]string{"PORT": "not-a-number"})
+	_, err = in.Expand("${PORT}", "!!int", Mark{Line: 1, Column: 1})
+	assert.Truef(t, err != nil, "expected a coercion error for a non-numeric !!int value")
+}
+
+func TestInterpolatorExpandScalarVerbatim(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{Lookup: lookupFrom(map[string]string{"HOST": "localhost"})}
+
+	got, err := in.ExpandScalar("${HOST}", "!!str", true, Mark{Line: 1, Column: 1})
+	assert.Truef(t, err == nil, "ExpandScalar returned an error: %v", err)
+	assert.Equalf(t, "${HOST}", got, "verbatim scalar should pass through unexpanded")
+}
+
+func TestInterpolatorExpandScalarExpands(t *testing.T) {
+	t.Parallel()
+
+	in := &Interpolator{Lookup: lookupFrom(map[string]string{"HOST": "localhost"})}
+
+	got, err := in.ExpandScalar("${HOST}", "!!str", false, Mark{Line: 1, Column: 1})
+	assert.Truef(t, err == nil, "ExpandScalar returned an error: %v", err)
+	assert.Equalf(t, "localhost", got, "non-verbatim scalar should be expanded")
+}
@@ -0,0 +1,292 @@
+// Copyright 2025 The go-yaml Project Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package yamlpatch implements overlay-style merging of YAML documents,
+// the idiom used by tools that let operators override a base config file
+// (e.g. foo.yaml) with a sibling patch file (e.g. foo.yaml.local).
+//
+// Merging operates on the parsed node tree rather than on decoded Go
+// values, so comments, anchors, and styles anywhere the patch does not
+// touch are carried over from the base document unchanged.
+package yamlpatch
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v4"
+	"go.yaml.in/yaml/v4/internal/libyaml"
+)
+
+// Strategy controls how a sequence node in the patch is combined with the
+// corresponding sequence node in the base document.
+type Strategy string
+
+const (
+	// StrategyReplace discards the base sequence entirely and keeps the
+	// patch's. This is the default for every sequence without an explicit
+	// hint.
+	StrategyReplace Strategy = "replace"
+	// StrategyAppend adds the patch sequence's items after the base's.
+	StrategyAppend Strategy = "append"
+	// StrategyPrepend adds the patch sequence's items before the base's.
+	StrategyPrepend Strategy = "prepend"
+	// StrategyMergeByIndex merges sequence items pairwise by position,
+	// recursing into each pair as if it were a mapping merge: base[0]
+	// merges with patch[0], base[1] with patch[1], and so on, with any
+	// leftover patch items appended. It does not match items by an
+	// identifying field (e.g. a shared "name" or "id"); there is no
+	// by-key matching here, only position. It is meant for sequences of
+	// mappings that are already known to line up element-for-element
+	// between base and patch.
+	StrategyMergeByIndex Strategy = "merge_by_index"
+)
+
+// mergeHintPrefix is the comment an operator can attach to a sequence key
+// in the patch document to override the default StrategyReplace, e.g.:
+//
+//	items: #!merge:append
+//	  - three
+const mergeHintPrefix = "#!merge:"
+
+// Options controls non-default merge behavior.
+type Options struct {
+	// Strategies maps a dotted YAML path in the patch document (e.g.
+	// "services.web.ports") to the Strategy used to combine that path's
+	// sequence with the base document's. A path not present here falls
+	// back to any "#!merge:" comment hint on the node, and then to
+	// StrategyReplace.
+	Strategies map[string]Strategy
+}
+
+// Merge combines patch onto base at the node level and returns the
+// resulting YAML document.
+//
+// Mappings merge key by key, recursively. A scalar in patch replaces the
+// corresponding scalar in base. A null scalar in patch deletes the
+// corresponding key from base (the null-to-delete idiom). Sequences are
+// replaced wholesale unless Options or a "#!merge:" hint says otherwise.
+// A type mismatch between base and patch at the same path (e.g. a
+// mapping in one and a sequence in the other) is reported as a
+// *libyaml.LoadError with Stage set to [libyaml.MergerStage], Mark
+// pointing at the offending patch node, and ContextMark pointing at the
+// corresponding base node.
+func Merge(base, patch []byte, opts *Options) ([]byte, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	var baseDoc, patchDoc yaml.Node
+	if err := yaml.Unmarshal(base, &baseDoc); err != nil {
+		return nil, fmt.Errorf("yamlpatch: parsing base: %w", err)
+	}
+	if err := yaml.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, fmt.Errorf("yamlpatch: parsing patch: %w", err)
+	}
+
+	if baseDoc.Kind == 0 {
+		return yaml.Marshal(&patchDoc)
+	}
+	if patchDoc.Kind == 0 {
+		return yaml.Marshal(&baseDoc)
+	}
+
+	merged, err := mergeNodes(documentRoot(&baseDoc), documentRoot(&patchDoc), "", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := baseDoc
+	out.Content = []*yaml.Node{merged}
+	return yaml.Marshal(&out)
+}
+
+// PatchFile reads path and, if a sibling "path.local" file exists, merges
+// it on top of path via Merge. If no ".local" file exists, the contents
+// of path are returned unchanged.
+func PatchFile(path string) ([]byte, error) {
+	base, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("yamlpatch: reading %s: %w", path, err)
+	}
+
+	patch, err := os.ReadFile(path + ".local")
+	if os.IsNotExist(err) {
+		return base, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("yamlpatch: reading %s.local: %w", path, err)
+	}
+
+	return Merge(base, patch, nil)
+}
+
+// documentRoot unwraps a top-level DocumentNode down to its single child,
+// which is what callers actually want to merge.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// mergeNodes merges patch onto base at path. hint is the mapping key node
+// that pointed at patch, if any — that's where go-yaml attaches a same-line
+// "key: #!merge:append" comment, not on patch itself. hint is nil when
+// patch is a document root or a bare sequence item with no key of its own.
+func mergeNodes(base, patch *yaml.Node, path string, hint *yaml.Node, opts *Options) (*yaml.Node, error) {
+	if base.Kind != patch.Kind {
+		return nil, typeMismatch(base, patch, path)
+	}
+
+	switch patch.Kind {
+	case yaml.MappingNode:
+		return mergeMappings(base, patch, path, opts)
+	case yaml.SequenceNode:
+		return mergeSequences(base, patch, path, hint, opts)
+	default:
+		// Scalars and aliases in patch simply replace base.
+		return patch, nil
+	}
+}
+
+func mergeMappings(base, patch *yaml.Node, path string, opts *Options) (*yaml.Node, error) {
+	out := shallowCopy(base)
+	out.Content = append([]*yaml.Node(nil), base.Content...)
+
+	for i := 0; i+1 < len(patch.Content); i += 2 {
+		key, val := patch.Content[i], patch.Content[i+1]
+		childPath := joinPath(path, key.Value)
+
+		idx := findKey(out.Content, key.Value)
+		switch {
+		case idx < 0 && isNull(val):
+			// Nothing to delete; ignore the hint.
+		case idx < 0:
+			out.Content = append(out.Content, key, val)
+		case isNull(val):
+			out.Content = append(out.Content[:idx], out.Content[idx+2:]...)
+		default:
+			merged, err := mergeNodes(out.Content[idx+1], val, childPath, key, opts)
+			if err != nil {
+				return nil, err
+			}
+			out.Content[idx+1] = merged
+		}
+	}
+
+	return out, nil
+}
+
+func mergeSequences(base, patch *yaml.Node, path string, hint *yaml.Node, opts *Options) (*yaml.Node, error) {
+	switch strategyFor(patch, hint, path, opts) {
+	case StrategyAppend:
+		out := shallowCopy(base)
+		out.Content = append(append([]*yaml.Node(nil), base.Content...), patch.Content...)
+		return out, nil
+
+	case StrategyPrepend:
+		out := shallowCopy(base)
+		out.Content = append(append([]*yaml.Node(nil), patch.Content...), base.Content...)
+		return out, nil
+
+	case StrategyMergeByIndex:
+		out := shallowCopy(base)
+		out.Content = make([]*yaml.Node, 0, len(base.Content))
+		for i, item := range base.Content {
+			if i >= len(patch.Content) {
+				out.Content = append(out.Content, item)
+				continue
+			}
+			merged, err := mergeNodes(item, patch.Content[i], fmt.Sprintf("%s[%d]", path, i), nil, opts)
+			if err != nil {
+				return nil, err
+			}
+			out.Content = append(out.Content, merged)
+		}
+		if len(patch.Content) > len(base.Content) {
+			out.Content = append(out.Content, patch.Content[len(base.Content):]...)
+		}
+		return out, nil
+
+	default:
+		return patch, nil
+	}
+}
+
+// strategyFor resolves the Strategy for the sequence at path: an explicit
+// Options entry wins, then a "#!merge:" comment hint, then StrategyReplace.
+// The hint is read off the mapping key node that points at the sequence
+// (where go-yaml attaches a same-line "key: #!merge:append" comment), not
+// off the sequence node itself; keyNode is nil when there is no key, in
+// which case the sequence node's own comments are consulted instead.
+func strategyFor(patch, keyNode *yaml.Node, path string, opts *Options) Strategy {
+	if s, ok := opts.Strategies[path]; ok {
+		return s
+	}
+
+	hintSource := patch
+	if keyNode != nil {
+		hintSource = keyNode
+	}
+	for _, c := range []string{hintSource.LineComment, hintSource.HeadComment} {
+		if hint, ok := strings.CutPrefix(strings.TrimSpace(c), mergeHintPrefix); ok {
+			return Strategy(strings.TrimSpace(hint))
+		}
+	}
+	return StrategyReplace
+}
+
+func findKey(content []*yaml.Node, key string) int {
+	for i := 0; i+1 < len(content); i += 2 {
+		if content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+func shallowCopy(n *yaml.Node) *yaml.Node {
+	c := *n
+	return &c
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func typeMismatch(base, patch *yaml.Node, path string) error {
+	if path == "" {
+		path = "."
+	}
+	return &libyaml.LoadError{
+		Stage:       libyaml.MergerStage,
+		Message:     fmt.Sprintf("cannot merge %s into %s at %q", describeKind(patch), describeKind(base), path),
+		Mark:        libyaml.Mark{Line: patch.Line, Column: patch.Column},
+		ContextMark: libyaml.Mark{Line: base.Line, Column: base.Column},
+		ContextMsg:  "base value defined here",
+	}
+}
+
+func describeKind(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a sequence"
+	case yaml.ScalarNode:
+		return "a scalar"
+	case yaml.AliasNode:
+		return "an alias"
+	default:
+		return "a node"
+	}
+}
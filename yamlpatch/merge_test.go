@@ -0,0 +1,191 @@
+// Copyright 2025 The go-yaml Project Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlpatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	yaml "go.yaml.in/yaml/v4"
+	"go.yaml.in/yaml/v4/internal/libyaml"
+	"go.yaml.in/yaml/v4/internal/testutil/assert"
+)
+
+func TestMergeMappingInMapping(t *testing.T) {
+	t.Parallel()
+
+	base := "server:\n  host: localhost\n  port: 8080\n"
+	patch := "server:\n  port: 9090\n"
+
+	got, err := Merge([]byte(base), []byte(patch), nil)
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string]map[string]any
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.Equalf(t, "localhost", data["server"]["host"], "unmodified key should be preserved")
+	assert.Equalf(t, 9090, data["server"]["port"], "patched key should be overridden")
+}
+
+func TestMergeSequenceDefaultsToReplace(t *testing.T) {
+	t.Parallel()
+
+	base := "items:\n  - one\n  - two\n"
+	patch := "items:\n  - three\n"
+
+	got, err := Merge([]byte(base), []byte(patch), nil)
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string][]string
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.DeepEqualf(t, []string{"three"}, data["items"], "sequence should be replaced by default")
+}
+
+func TestMergeSequenceAppendViaOptions(t *testing.T) {
+	t.Parallel()
+
+	base := "items:\n  - one\n  - two\n"
+	patch := "items:\n  - three\n"
+
+	got, err := Merge([]byte(base), []byte(patch), &Options{
+		Strategies: map[string]Strategy{"items": StrategyAppend},
+	})
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string][]string
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.DeepEqualf(t, []string{"one", "two", "three"}, data["items"], "strategy hint should append")
+}
+
+func TestMergeSequenceAppendViaCommentHint(t *testing.T) {
+	t.Parallel()
+
+	base := "items:\n  - one\n  - two\n"
+	patch := "items: #!merge:append\n  - three\n"
+
+	got, err := Merge([]byte(base), []byte(patch), nil)
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string][]string
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.DeepEqualf(t, []string{"one", "two", "three"}, data["items"], "comment hint should append")
+}
+
+func TestMergeSequencePrependViaOptions(t *testing.T) {
+	t.Parallel()
+
+	base := "items:\n  - one\n  - two\n"
+	patch := "items:\n  - zero\n"
+
+	got, err := Merge([]byte(base), []byte(patch), &Options{
+		Strategies: map[string]Strategy{"items": StrategyPrepend},
+	})
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string][]string
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.DeepEqualf(t, []string{"zero", "one", "two"}, data["items"], "strategy hint should prepend")
+}
+
+func TestMergeSequenceByIndexMergesPairwise(t *testing.T) {
+	t.Parallel()
+
+	base := "servers:\n  - name: a\n    port: 8080\n  - name: b\n    port: 8081\n"
+	patch := "servers:\n  - port: 9090\n  - name: c\n"
+
+	got, err := Merge([]byte(base), []byte(patch), &Options{
+		Strategies: map[string]Strategy{"servers": StrategyMergeByIndex},
+	})
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string][]map[string]any
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	servers := data["servers"]
+	assert.Equalf(t, 2, len(servers), "expected 2 merged servers")
+	assert.Equalf(t, "a", servers[0]["name"], "servers[0].name should be unchanged by position-based merge")
+	assert.Equalf(t, 9090, servers[0]["port"], "servers[0].port should be overridden by position")
+	assert.Equalf(t, "c", servers[1]["name"], "servers[1].name should be overridden by position, not by matching on \"b\"")
+	assert.Equalf(t, 8081, servers[1]["port"], "servers[1].port should be preserved from base")
+}
+
+func TestMergeSequenceByIndexAppendsExtraPatchItems(t *testing.T) {
+	t.Parallel()
+
+	base := "items:\n  - one\n"
+	patch := "items:\n  - uno\n  - two\n"
+
+	got, err := Merge([]byte(base), []byte(patch), &Options{
+		Strategies: map[string]Strategy{"items": StrategyMergeByIndex},
+	})
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string][]string
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.DeepEqualf(t, []string{"uno", "two"}, data["items"], "extra patch items beyond base's length should be appended")
+}
+
+func TestMergeNullDeletesKey(t *testing.T) {
+	t.Parallel()
+
+	base := "server:\n  host: localhost\n  debug: true\n"
+	patch := "server:\n  debug: null\n"
+
+	got, err := Merge([]byte(base), []byte(patch), nil)
+	assert.Truef(t, err == nil, "Merge returned an error: %v", err)
+
+	var data map[string]map[string]any
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	_, hasDebug := data["server"]["debug"]
+	assert.Truef(t, !hasDebug, "null in patch should delete the key")
+	assert.Equalf(t, "localhost", data["server"]["host"], "unrelated key should survive")
+}
+
+func TestMergeTypeMismatchReportsLoadError(t *testing.T) {
+	t.Parallel()
+
+	base := "server:\n  host: localhost\n"
+	patch := "server: not-a-mapping\n"
+
+	_, err := Merge([]byte(base), []byte(patch), nil)
+	assert.Truef(t, err != nil, "expected a type mismatch error")
+
+	var loadErr *libyaml.LoadError
+	assert.Truef(t, errors.As(err, &loadErr), "error should be a *libyaml.LoadError, got %T", err)
+	assert.Equalf(t, libyaml.MergerStage, loadErr.Stage, "Stage should be MergerStage")
+	assert.Truef(t, loadErr.Mark.Line > 0, "Mark should point at the patch location")
+	assert.Truef(t, loadErr.ContextMark.Line > 0, "ContextMark should point at the base location")
+}
+
+func TestPatchFileMergesLocalOverride(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	local := base + ".local"
+
+	assert.Truef(t, os.WriteFile(base, []byte("server:\n  host: localhost\n  port: 8080\n"), 0o600) == nil, "failed to write base")
+	assert.Truef(t, os.WriteFile(local, []byte("server:\n  port: 9090\n"), 0o600) == nil, "failed to write local override")
+
+	got, err := PatchFile(base)
+	assert.Truef(t, err == nil, "PatchFile returned an error: %v", err)
+
+	var data map[string]map[string]any
+	assert.Truef(t, yaml.Unmarshal(got, &data) == nil, "result did not parse as YAML")
+	assert.Equalf(t, "localhost", data["server"]["host"], "unmodified key should be preserved")
+	assert.Equalf(t, 9090, data["server"]["port"], "patched key should be overridden")
+}
+
+func TestPatchFileWithoutLocalReturnsBaseUnchanged(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	content := "server:\n  host: localhost\n"
+	assert.Truef(t, os.WriteFile(base, []byte(content), 0o600) == nil, "failed to write base")
+
+	got, err := PatchFile(base)
+	assert.Truef(t, err == nil, "PatchFile returned an error: %v", err)
+	assert.Equalf(t, content, string(got), "without a .local file the base content should be unchanged")
+}